@@ -0,0 +1,179 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE.txt file in the root of the source
+// tree.
+
+package timestamp
+
+import "time"
+
+const (
+	nsPerSecond = int64(time.Second)
+	nsPerDay    = int64(24 * time.Hour)
+)
+
+// divmod returns q, r such that ts = q*d + r, with 0 <= r < d
+// (floor division, as opposed to Go's truncating / and %).
+func divmod(ts, d int64) (q, r int64) {
+	q = ts / d
+	r = ts % d
+	if r < 0 {
+		q--
+		r += d
+	}
+	return q, r
+}
+
+// civilFromDays converts z, the number of days since 1970-01-01 (it may
+// be negative), into a proleptic Gregorian year, month (1-12) and day
+// (1-31). It implements Howard Hinnant's civil_from_days algorithm.
+func civilFromDays(z int64) (year int64, month int, day int) {
+	z += 719468 // shift epoch from 1970-01-01 to 0000-03-01
+	era, doe := divmod(z, 146097)
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // [0, 399]
+	y := yoe + era*400
+	doy := doe - (365*yoe + yoe/4 - yoe/100) // [0, 365]
+	mp := (5*doy + 2) / 153                  // [0, 11]
+	d := doy - (153*mp+2)/5 + 1               // [1, 31]
+	m := mp + 3                               // [3, 14], normalized below
+	if mp >= 10 {
+		m = mp - 9 // [1, 2]
+		y++
+	}
+	return y, int(m), int(d)
+}
+
+// daysFromCivil returns the number of days since 1970-01-01 for the given
+// proleptic Gregorian year/month/day. It is the inverse of civilFromDays.
+func daysFromCivil(year int64, month, day int) int64 {
+	y := year
+	if month <= 2 {
+		y--
+	}
+	era, _ := divmod(y, 400)
+	yoe := y - era*400 // [0, 399]
+	var mp int64
+	if month > 2 {
+		mp = int64(month) - 3
+	} else {
+		mp = int64(month) + 9
+	}
+	doy := (153*mp+2)/5 + int64(day) - 1 // [0, 365]
+	doe := yoe*365 + yoe/4 - yoe/100 + doy // [0, 146096]
+	return era*146097 + doe - 719468
+}
+
+// civil holds the result of splitting a TS into a calendar date & time,
+// all computed with integer arithmetic on the underlying nanosecond
+// count, without going through time.Time.
+type civil struct {
+	year       int64
+	month, day int
+	hour, min, sec int
+	nsec       int
+	days       int64 // days since 1970-01-01 (may be negative)
+	nsecOfDay  int64 // [0, nsPerDay)
+}
+
+// split decomposes ts into its calendar components.
+// The zero TS is special-cased to match Time(), which maps it to the
+// zero time.Time (0001-01-01 00:00:00) rather than to the Unix epoch.
+func (ts TS) split() civil {
+	if ts.IsZero() {
+		return civil{year: 1, month: 1, day: 1, days: daysFromCivil(1, 1, 1)}
+	}
+	days, nsecOfDay := divmod(int64(ts), nsPerDay)
+	year, month, day := civilFromDays(days)
+	secOfDay, nsec := divmod(nsecOfDay, nsPerSecond)
+	hour := secOfDay / 3600
+	min := (secOfDay / 60) % 60
+	sec := secOfDay % 60
+	return civil{
+		year: year, month: month, day: day,
+		hour: int(hour), min: int(min), sec: int(sec), nsec: int(nsec),
+		days: days, nsecOfDay: nsecOfDay,
+	}
+}
+
+// Date returns the year, month and day corresponding to ts, in UTC.
+func (ts TS) Date() (year int, month time.Month, day int) {
+	c := ts.split()
+	return int(c.year), time.Month(c.month), c.day
+}
+
+// Year returns the year corresponding to ts, in UTC.
+func (ts TS) Year() int {
+	return int(ts.split().year)
+}
+
+// Month returns the month of the year corresponding to ts, in UTC.
+func (ts TS) Month() time.Month {
+	return time.Month(ts.split().month)
+}
+
+// Day returns the day of the month corresponding to ts, in UTC.
+func (ts TS) Day() int {
+	return ts.split().day
+}
+
+// Clock returns the hour, minute and second corresponding to ts, in UTC.
+func (ts TS) Clock() (hour, min, sec int) {
+	c := ts.split()
+	return c.hour, c.min, c.sec
+}
+
+// Hour returns the hour within the day corresponding to ts, in the range
+// [0, 23], in UTC.
+func (ts TS) Hour() int {
+	return ts.split().hour
+}
+
+// Minute returns the minute offset within the hour corresponding to ts,
+// in the range [0, 59].
+func (ts TS) Minute() int {
+	return ts.split().min
+}
+
+// Second returns the second offset within the minute corresponding to
+// ts, in the range [0, 59].
+func (ts TS) Second() int {
+	return ts.split().sec
+}
+
+// Nanosecond returns the nanosecond offset within the second specified
+// by ts, in the range [0, 999999999].
+func (ts TS) Nanosecond() int {
+	return ts.split().nsec
+}
+
+// Weekday returns the day of the week corresponding to ts, in UTC.
+func (ts TS) Weekday() time.Weekday {
+	_, wd := divmod(ts.split().days+4, 7) // 1970-01-01 was a Thursday (day 4)
+	return time.Weekday(wd)
+}
+
+// YearDay returns the day of the year corresponding to ts, in the range
+// [1,365] for non-leap years, and [1,366] in leap years.
+func (ts TS) YearDay() int {
+	c := ts.split()
+	return int(c.days - daysFromCivil(c.year, 1, 1) + 1)
+}
+
+// ISOWeek returns the ISO 8601 year and week number in which ts occurs,
+// in UTC. Week ranges from 1 to 53. Jan 01 to Jan 03 of year n might
+// belong to week 52 or 53 of year n-1, and Dec 29 to Dec 31 might belong
+// to week 1 of year n+1.
+func (ts TS) ISOWeek() (year, week int) {
+	c := ts.split()
+	// ISO weekday, Monday == 1 ... Sunday == 7
+	wd := int(ts.Weekday())
+	if wd == 0 {
+		wd = 7
+	}
+	// Thursday of this ISO week determines the ISO year.
+	thursday := c.days - int64(wd) + 4
+	isoYear, _, _ := civilFromDays(thursday)
+	week = int((thursday-daysFromCivil(isoYear, 1, 1))/7) + 1
+	return int(isoYear), week
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func tstCivilEq(t *testing.T, prefix string, ts TS) int {
+	var errs int
+	u := ts.Time()
+
+	year, month, day := ts.Date()
+	uYear, uMonth, uDay := u.Date()
+	if year != uYear || month != uMonth || day != uDay {
+		t.Errorf(prefix+"Date() mismatch for %s: got %d-%s-%d, want %d-%s-%d\n",
+			ts, year, month, day, uYear, uMonth, uDay)
+		errs++
+	}
+	if ts.Year() != u.Year() {
+		t.Errorf(prefix+"Year() mismatch for %s: got %d, want %d\n",
+			ts, ts.Year(), u.Year())
+		errs++
+	}
+	if ts.Month() != u.Month() {
+		t.Errorf(prefix+"Month() mismatch for %s: got %s, want %s\n",
+			ts, ts.Month(), u.Month())
+		errs++
+	}
+	if ts.Day() != u.Day() {
+		t.Errorf(prefix+"Day() mismatch for %s: got %d, want %d\n",
+			ts, ts.Day(), u.Day())
+		errs++
+	}
+
+	hour, min, sec := ts.Clock()
+	uHour, uMin, uSec := u.Clock()
+	if hour != uHour || min != uMin || sec != uSec {
+		t.Errorf(prefix+"Clock() mismatch for %s: got %d:%d:%d, want %d:%d:%d\n",
+			ts, hour, min, sec, uHour, uMin, uSec)
+		errs++
+	}
+	if ts.Nanosecond() != u.Nanosecond() {
+		t.Errorf(prefix+"Nanosecond() mismatch for %s: got %d, want %d\n",
+			ts, ts.Nanosecond(), u.Nanosecond())
+		errs++
+	}
+	if ts.Weekday() != u.Weekday() {
+		t.Errorf(prefix+"Weekday() mismatch for %s: got %s, want %s\n",
+			ts, ts.Weekday(), u.Weekday())
+		errs++
+	}
+	if ts.YearDay() != u.YearDay() {
+		t.Errorf(prefix+"YearDay() mismatch for %s: got %d, want %d\n",
+			ts, ts.YearDay(), u.YearDay())
+		errs++
+	}
+	year, week := ts.ISOWeek()
+	uYear, uWeek := u.ISOWeek()
+	if year != uYear || week != uWeek {
+		t.Errorf(prefix+"ISOWeek() mismatch for %s: got %d/%d, want %d/%d\n",
+			ts, year, week, uYear, uWeek)
+		errs++
+	}
+	return errs
+}
+
+func TestCivilZero(t *testing.T) {
+	if errs := tstCivilEq(t, "zero: ", TS(0)); errs != 0 {
+		t.Errorf("zero TS: %d errors\n", errs)
+	}
+}
+
+func TestCivilMinMax(t *testing.T) {
+	if errs := tstCivilEq(t, "max: ", MaxTS); errs != 0 {
+		t.Errorf("MaxTS: %d errors\n", errs)
+	}
+	if errs := tstCivilEq(t, "min: ", MinTS); errs != 0 {
+		t.Errorf("MinTS: %d errors\n", errs)
+	}
+}
+
+func TestCivilRand(t *testing.T) {
+	const cfgIterations = 10000
+	for i := 0; i < cfgIterations; i++ {
+		ts1 := TS(rand.Int63n(int64(MaxTS)))
+		if errs := tstCivilEq(t, "rand+: ", ts1); errs != 0 {
+			t.Fatalf("rand+: %d errors for ts %d, seed %d\n", errs, ts1, seed)
+		}
+		ts2 := TS(-rand.Int63n(int64(-(MinTS + 1))))
+		if errs := tstCivilEq(t, "rand-: ", ts2); errs != 0 {
+			t.Fatalf("rand-: %d errors for ts %d, seed %d\n", errs, ts2, seed)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE.txt file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// JSONFormat selects how TS is encoded by MarshalJSON/MarshalText and
+// decoded by the corresponding Unmarshal methods.
+type JSONFormat int32
+
+const (
+	// FmtRFC3339 encodes TS the same way time.Time does (RFC3339 string
+	// with nanosecond precision). This is the default, kept for
+	// backwards compatibility.
+	FmtRFC3339 JSONFormat = iota
+	// FmtUnix encodes TS as a JSON number: seconds since the Unix epoch.
+	FmtUnix
+	// FmtUnixMilli encodes TS as a JSON number: milliseconds since the
+	// Unix epoch.
+	FmtUnixMilli
+	// FmtUnixMicro encodes TS as a JSON number: microseconds since the
+	// Unix epoch.
+	FmtUnixMicro
+	// FmtUnixNano encodes TS as a JSON number: nanoseconds since the
+	// Unix epoch.
+	FmtUnixNano
+)
+
+// ErrOutOfRange is returned whenever a conversion would produce a TS
+// outside the ~1678-2262 representable range.
+var ErrOutOfRange = errors.New("timestamp: value out of range")
+
+// jsonFormat holds the current package-wide JSONFormat, stored as int32
+// so it can be read/changed concurrently with SetJSONFormat/JSONFormatUsed.
+var jsonFormat int32 = int32(FmtRFC3339)
+
+// SetJSONFormat changes, for the whole process, the encoding used by
+// MarshalJSON/MarshalText (and the corresponding Unmarshal methods) for
+// all TS values. The default is FmtRFC3339.
+// It is intended to be called once, e.g. from main() or from an init(),
+// before any marshaling happens: it is safe for concurrent use, but
+// changing the format while values are being encoded/decoded elsewhere
+// can result in a mix of the old and new formats.
+func SetJSONFormat(f JSONFormat) {
+	atomic.StoreInt32(&jsonFormat, int32(f))
+}
+
+// JSONFormatUsed returns the JSONFormat currently used for
+// marshaling/unmarshaling.
+func JSONFormatUsed() JSONFormat {
+	return JSONFormat(atomic.LoadInt32(&jsonFormat))
+}
+
+// unitNanoseconds returns the number of nanoseconds corresponding to one
+// unit of f. FmtRFC3339, which has no unit of its own, is treated as
+// nanoseconds, like FmtUnixNano.
+func (f JSONFormat) unitNanoseconds() int64 {
+	switch f {
+	case FmtUnix:
+		return int64(time.Second)
+	case FmtUnixMilli:
+		return int64(time.Millisecond)
+	case FmtUnixMicro:
+		return int64(time.Microsecond)
+	default: // FmtUnixNano
+		return 1
+	}
+}
+
+// int64ToNanoseconds converts v, expressed in f's unit, to nanoseconds
+// since the epoch, failing with ErrOutOfRange on overflow.
+func (f JSONFormat) int64ToNanoseconds(v int64) (int64, error) {
+	unit := f.unitNanoseconds()
+	if unit == 1 {
+		return v, nil
+	}
+	ns := v * unit
+	if v != 0 && ns/unit != v {
+		return 0, ErrOutOfRange
+	}
+	return ns, nil
+}
+
+// float64ToNanoseconds converts v, expressed in f's unit, to nanoseconds
+// since the epoch, failing with ErrOutOfRange on overflow. It is used for
+// JSON numbers that do not fit in an int64 textual representation (e.g.
+// scientific notation like 1.3e2).
+func (f JSONFormat) float64ToNanoseconds(v float64) (int64, error) {
+	ns := v * float64(f.unitNanoseconds())
+	// float64(MaxTS) rounds up to 2^63, which does not fit in an int64
+	if ns < float64(MinTS) || ns >= float64(MaxTS) {
+		return 0, ErrOutOfRange
+	}
+	return int64(ns), nil
+}
+
+// marshalCompact returns the JSON number encoding of ts for the given
+// format (which must not be FmtRFC3339).
+func (ts TS) marshalCompact(f JSONFormat) []byte {
+	unit := f.unitNanoseconds()
+	if unit == 1 {
+		return strconv.AppendInt(nil, int64(ts), 10)
+	}
+	return strconv.AppendInt(nil, int64(ts)/unit, 10)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The encoding used depends on the package-wide format set with
+// SetJSONFormat: the default, FmtRFC3339, matches time.Time.MarshalJSON;
+// the other formats encode ts as a JSON number of seconds, milliseconds,
+// microseconds or nanoseconds since the Unix epoch.
+func (ts TS) MarshalJSON() ([]byte, error) {
+	if f := JSONFormatUsed(); f != FmtRFC3339 {
+		return ts.marshalCompact(f), nil
+	}
+	return ts.Time().MarshalJSON()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// See MarshalJSON for how the format is chosen.
+func (ts TS) MarshalText() ([]byte, error) {
+	if f := JSONFormatUsed(); f != FmtRFC3339 {
+		return ts.marshalCompact(f), nil
+	}
+	return ts.Time().MarshalText()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (ts TS) MarshalBinary() ([]byte, error) {
+	return ts.Time().MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (ts *TS) UnmarshalBinary(data []byte) error {
+	var t time.Time
+	if err := t.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*ts = Timestamp(t)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts both a JSON number, interpreted according to the
+// package-wide format set with SetJSONFormat (seconds/milli/micro/nano
+// since the Unix epoch), and a JSON string holding an RFC3339 timestamp,
+// regardless of the configured format.
+func (ts *TS) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*ts = 0
+		return nil
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		*ts = Timestamp(t)
+		return nil
+	}
+	ns, err := parseJSONNumber(data, JSONFormatUsed())
+	if err != nil {
+		return err
+	}
+	*ts = TS(ns)
+	return nil
+}
+
+// parseJSONNumber converts a JSON number (data) expressed in f's unit
+// into nanoseconds since the epoch. It accepts both plain integers and
+// scientific-notation forms (e.g. 1.3e2).
+func parseJSONNumber(data []byte, f JSONFormat) (int64, error) {
+	if f == FmtRFC3339 {
+		// no unit configured: fall back to nanoseconds, the most
+		// precise interpretation of a bare JSON number
+		f = FmtUnixNano
+	}
+	if v, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+		return f.int64ToNanoseconds(v)
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return 0, err
+	}
+	return f.float64ToNanoseconds(v)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// See UnmarshalJSON for the accepted formats, with one difference: with
+// the default FmtRFC3339 format only an RFC3339 timestamp is accepted,
+// not a bare number.
+func (ts *TS) UnmarshalText(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		*ts = 0
+		return nil
+	}
+	if f := JSONFormatUsed(); f != FmtRFC3339 {
+		if ns, err := parseJSONNumber(data, f); err == nil {
+			*ts = TS(ns)
+			return nil
+		}
+	}
+	var t time.Time
+	if err := t.UnmarshalText(data); err != nil {
+		return err
+	}
+	*ts = Timestamp(t)
+	return nil
+}
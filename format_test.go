@@ -0,0 +1,104 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONRFC3339RoundTrip(t *testing.T) {
+	SetJSONFormat(FmtRFC3339)
+	ts := Timestamp(time.Now())
+	b, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatalf("Marshal failed: %s\n", err)
+	}
+	var ts2 TS
+	if err := json.Unmarshal(b, &ts2); err != nil {
+		t.Fatalf("Unmarshal failed: %s\n", err)
+	}
+	if !ts.Equal(ts2) {
+		t.Errorf("round trip failed: %s <> %s\n", ts, ts2)
+	}
+}
+
+func TestJSONCompactRoundTrip(t *testing.T) {
+	defer SetJSONFormat(FmtRFC3339)
+	formats := []JSONFormat{FmtUnix, FmtUnixMilli, FmtUnixMicro, FmtUnixNano}
+	values := []TS{0, Timestamp(time.Now()), MaxTS, MinTS + 1}
+	for _, f := range formats {
+		SetJSONFormat(f)
+		unit := f.unitNanoseconds()
+		for _, ts := range values {
+			b, err := json.Marshal(ts)
+			if err != nil {
+				t.Fatalf("format %d: Marshal(%s) failed: %s\n", f, ts, err)
+			}
+			var ts2 TS
+			if err := json.Unmarshal(b, &ts2); err != nil {
+				t.Fatalf("format %d: Unmarshal(%s) failed: %s\n", f, b, err)
+			}
+			// lossy formats (everything but FmtUnixNano) only round trip
+			// up to truncation to the unit's precision
+			want := TS((int64(ts) / unit) * unit)
+			if ts2 != want {
+				t.Errorf("format %d: round trip failed for %d: got %d, want %d\n",
+					f, ts, ts2, want)
+			}
+		}
+	}
+}
+
+func TestJSONCompactZero(t *testing.T) {
+	defer SetJSONFormat(FmtRFC3339)
+	SetJSONFormat(FmtUnixMilli)
+	b, err := json.Marshal(TS(0))
+	if err != nil {
+		t.Fatalf("Marshal failed: %s\n", err)
+	}
+	if string(b) != "0" {
+		t.Errorf("expected \"0\", got %q\n", b)
+	}
+	var ts TS
+	if err := json.Unmarshal(b, &ts); err != nil {
+		t.Fatalf("Unmarshal failed: %s\n", err)
+	}
+	if !ts.IsZero() {
+		t.Errorf("expected zero TS, got %s\n", ts)
+	}
+}
+
+func TestJSONCompactScientific(t *testing.T) {
+	defer SetJSONFormat(FmtRFC3339)
+	SetJSONFormat(FmtUnix)
+	var ts TS
+	if err := json.Unmarshal([]byte("1.3e2"), &ts); err != nil {
+		t.Fatalf("Unmarshal failed: %s\n", err)
+	}
+	if ts != TS(130*time.Second) {
+		t.Errorf("expected %d, got %d\n", TS(130*time.Second), ts)
+	}
+}
+
+func TestJSONAcceptsRFC3339StringInCompactMode(t *testing.T) {
+	defer SetJSONFormat(FmtRFC3339)
+	SetJSONFormat(FmtUnixNano)
+	u := time.Now().UTC()
+	b, err := u.MarshalJSON()
+	if err != nil {
+		t.Fatalf("time.MarshalJSON failed: %s\n", err)
+	}
+	var ts TS
+	if err := json.Unmarshal(b, &ts); err != nil {
+		t.Fatalf("Unmarshal failed: %s\n", err)
+	}
+	if !ts.EqualTime(u) {
+		t.Errorf("expected %s, got %s\n", u, ts)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE.txt file in the root of the source
+// tree.
+
+package timestamp
+
+import "time"
+
+// maxRepDays/minRepDays bound the number of days since 1970-01-01 that
+// can still be multiplied by nsPerDay without overflowing an int64 (i.e.
+// the day component of MaxTS/MinTS).
+const (
+	maxRepDays = int64(MaxTS) / nsPerDay
+	minRepDays = int64(MinTS) / nsPerDay
+)
+
+// daysInMonthTable holds the day count for each month in a non-leap year.
+var daysInMonthTable = [...]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// isLeapYear returns true if year is a leap year in the proleptic
+// Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth returns the number of days in the given month (1-12) of
+// year, assumed already validated to be in [1, 12].
+func daysInMonth(year, month int) int {
+	if month == 2 && isLeapYear(year) {
+		return 29
+	}
+	return daysInMonthTable[month-1]
+}
+
+// Parse parses a formatted string and returns the TS value it represents,
+// interpreting it in the same way as time.Parse (see time.Parse for the
+// supported layouts). If the parsed instant falls outside the
+// representable ~1678-2262 range, Parse returns MaxTS or MinTS together
+// with ErrOutOfRange, rather than silently wrapping. The zero time.Time
+// maps to the zero TS, as with Timestamp.
+func Parse(layout, value string) (TS, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return 0, err
+	}
+	return tsFromTime(t)
+}
+
+// ParseInLocation is like Parse but interprets the time as in the given
+// location when the layout does not contain a time zone.
+// See time.ParseInLocation for details.
+func ParseInLocation(layout, value string, loc *time.Location) (TS, error) {
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return 0, err
+	}
+	return tsFromTime(t)
+}
+
+// ParseRFC3339Nano parses value as an RFC3339 timestamp with optional
+// fractional seconds (the format produced by TS.Format with
+// time.RFC3339Nano), and returns the corresponding TS.
+// It uses a fast path that decodes the fixed-width digits directly into
+// a nanosecond count, without allocating a time.Time, falling back to
+// the general time.Parse machinery for less common, but still valid,
+// RFC3339 variants (e.g. a ":60" leap second).
+func ParseRFC3339Nano(value string) (TS, error) {
+	if ns, rangeErr, ok := parseRFC3339NanoFast(value); ok {
+		if rangeErr {
+			if ns > 0 {
+				return MaxTS, ErrOutOfRange
+			}
+			return MinTS, ErrOutOfRange
+		}
+		return TS(ns), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return 0, err
+	}
+	return tsFromTime(t)
+}
+
+// tsFromTime converts t to a TS, preserving the zero-time.Time <-> zero-TS
+// mapping used by Timestamp, and reporting ErrOutOfRange (together with
+// the saturated MaxTS/MinTS) instead of silently wrapping.
+func tsFromTime(t time.Time) (TS, error) {
+	if t.IsZero() {
+		return 0, nil
+	}
+	if OutOfRange(t) {
+		if t.Before(tZero) {
+			return MinTS, ErrOutOfRange
+		}
+		return MaxTS, ErrOutOfRange
+	}
+	return Timestamp(t), nil
+}
+
+// parseRFC3339NanoFast decodes value's fixed-width digits directly,
+// without going through time.Parse. ok is false if value does not match
+// the expected "2006-01-02T15:04:05[.999999999](Z|+07:00)" shape, or if
+// its date lies within a few days of the edge of the representable TS
+// range, in which case the caller should fall back to time.Parse. rangeErr is true
+// if value parsed fine but falls outside the representable TS range, in
+// which case ns carries the sign of the out-of-range value (but not a
+// usable magnitude).
+func parseRFC3339NanoFast(s string) (ns int64, rangeErr bool, ok bool) {
+	if len(s) < 20 {
+		return 0, false, false
+	}
+	digits := func(start, n int) (int, bool) {
+		if start+n > len(s) {
+			return 0, false
+		}
+		v := 0
+		for i := 0; i < n; i++ {
+			c := s[start+i]
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			v = v*10 + int(c-'0')
+		}
+		return v, true
+	}
+	year, ok1 := digits(0, 4)
+	month, ok2 := digits(5, 2)
+	day, ok3 := digits(8, 2)
+	hour, ok4 := digits(11, 2)
+	min, ok5 := digits(14, 2)
+	sec, ok6 := digits(17, 2)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 ||
+		s[4] != '-' || s[7] != '-' || (s[10] != 'T' && s[10] != 't') ||
+		s[13] != ':' || s[16] != ':' {
+		return 0, false, false
+	}
+	if month < 1 || month > 12 ||
+		hour > 23 || min > 59 || sec > 59 {
+		return 0, false, false
+	}
+	if day < 1 || day > daysInMonth(year, month) {
+		return 0, false, false
+	}
+
+	i := 19
+	var nsec int
+	if i < len(s) && s[i] == '.' {
+		i++
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		fracLen := i - start
+		if fracLen == 0 || fracLen > 9 {
+			return 0, false, false
+		}
+		for j := start; j < i; j++ {
+			nsec = nsec*10 + int(s[j]-'0')
+		}
+		for k := fracLen; k < 9; k++ {
+			nsec *= 10
+		}
+	}
+	if i >= len(s) {
+		return 0, false, false
+	}
+
+	var offsetSec int
+	switch s[i] {
+	case 'Z', 'z':
+		if i != len(s)-1 {
+			return 0, false, false
+		}
+	case '+', '-':
+		if len(s)-i != 6 || s[i+3] != ':' {
+			return 0, false, false
+		}
+		oh, ok7 := digits(i+1, 2)
+		om, ok8 := digits(i+4, 2)
+		if !ok7 || !ok8 {
+			return 0, false, false
+		}
+		offsetSec = oh*3600 + om*60
+		if s[i] == '-' {
+			offsetSec = -offsetSec
+		}
+	default:
+		return 0, false, false
+	}
+
+	if year == 1 && month == 1 && day == 1 &&
+		hour == 0 && min == 0 && sec == 0 && nsec == 0 && offsetSec == 0 {
+		// zero time.Time: Timestamp/tsFromTime map it to the zero TS,
+		// not to the (out of range) instant it would otherwise denote
+		return 0, false, true
+	}
+
+	days := daysFromCivil(int64(year), month, day)
+	// the zone offset (at most 99:99) moves the instant by less than 5
+	// days, so a date further than that past maxRepDays/minRepDays is out
+	// of range whatever its time of day and offset
+	if days > maxRepDays+5 || days < minRepDays-5 {
+		if days > 0 {
+			return 1, true, true
+		}
+		return -1, true, true
+	}
+	if days >= maxRepDays-5 || days <= minRepDays+5 {
+		// near the edge of the range the time of day and the offset
+		// decide: leave it to the time.Parse fallback
+		return 0, false, false
+	}
+	secOfDay := int64(hour)*3600 + int64(min)*60 + int64(sec) - int64(offsetSec)
+	intraDayNs := secOfDay*nsPerSecond + int64(nsec)
+	return days*nsPerDay + intraDayNs, false, true
+}
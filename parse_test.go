@@ -0,0 +1,135 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestParseRFC3339NanoRoundTrip(t *testing.T) {
+	const cfgIterations = 1000
+	for i := 0; i < cfgIterations; i++ {
+		ts1 := TS(rand.Int63n(int64(MaxTS - TS(time.Hour))))
+		s := ts1.Time().Format(time.RFC3339Nano)
+		ts2, err := ParseRFC3339Nano(s)
+		if err != nil {
+			t.Fatalf("ParseRFC3339Nano(%q) failed: %s, seed %d\n", s, err, seed)
+		}
+		if !ts1.Equal(ts2) {
+			t.Errorf("round trip mismatch for %q: %s <> %s, seed %d\n",
+				s, ts1, ts2, seed)
+		}
+	}
+}
+
+func TestParseRFC3339NanoZero(t *testing.T) {
+	ts, err := ParseRFC3339Nano("1970-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseRFC3339Nano failed: %s\n", err)
+	}
+	if !ts.IsZero() {
+		t.Errorf("expected zero TS, got %s\n", ts)
+	}
+}
+
+func TestParseRFC3339NanoOffset(t *testing.T) {
+	ts, err := ParseRFC3339Nano("1970-01-01T01:30:00+01:30")
+	if err != nil {
+		t.Fatalf("ParseRFC3339Nano failed: %s\n", err)
+	}
+	if !ts.IsZero() {
+		t.Errorf("expected zero TS, got %s\n", ts)
+	}
+}
+
+func TestParseRFC3339NanoInvalid(t *testing.T) {
+	if _, err := ParseRFC3339Nano("not-a-timestamp"); err == nil {
+		t.Errorf("expected an error for an invalid timestamp\n")
+	}
+}
+
+func TestParseRFC3339NanoCalendarInvalid(t *testing.T) {
+	invalid := []string{
+		"2023-02-30T00:00:00Z", // February never has 30 days
+		"2023-04-31T00:00:00Z", // April has 30 days
+		"2023-02-29T00:00:00Z", // 2023 is not a leap year
+		"2023-13-01T00:00:00Z", // no month 13
+		"2023-00-01T00:00:00Z", // no month 0
+	}
+	for _, s := range invalid {
+		if _, err := ParseRFC3339Nano(s); err == nil {
+			t.Errorf("ParseRFC3339Nano(%q): expected an error\n", s)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			t.Errorf("time.Parse(%q): expected an error (test is wrong)\n", s)
+		}
+	}
+}
+
+func TestParseOutOfRange(t *testing.T) {
+	ts, err := ParseRFC3339Nano("9999-01-01T00:00:00Z")
+	if err != ErrOutOfRange {
+		t.Fatalf("expected ErrOutOfRange, got %v\n", err)
+	}
+	if ts != MaxTS {
+		t.Errorf("expected MaxTS, got %s\n", ts)
+	}
+
+	ts, err = ParseRFC3339Nano("0002-01-01T00:00:00Z")
+	if err != ErrOutOfRange {
+		t.Fatalf("expected ErrOutOfRange, got %v\n", err)
+	}
+	if ts != MinTS {
+		t.Errorf("expected MinTS, got %s\n", ts)
+	}
+}
+
+func TestParseRFC3339NanoZeroTime(t *testing.T) {
+	// the zero time.Time, even though 0001-01-01 is itself far outside
+	// the representable TS range, must map to the zero TS, same as
+	// Timestamp(time.Time{}) and Parse(time.RFC3339Nano, ...) do
+	ts, err := ParseRFC3339Nano("0001-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseRFC3339Nano failed: %s\n", err)
+	}
+	if !ts.IsZero() {
+		t.Errorf("expected zero TS, got %s\n", ts)
+	}
+
+	tsSlow, err := Parse(time.RFC3339Nano, "0001-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Parse failed: %s\n", err)
+	}
+	if !ts.Equal(tsSlow) {
+		t.Errorf("fast and slow paths disagree: %s <> %s\n", ts, tsSlow)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	const layout = "2006-01-02 15:04:05"
+	ts, err := Parse(layout, "1970-01-01 00:00:01")
+	if err != nil {
+		t.Fatalf("Parse failed: %s\n", err)
+	}
+	if ts != TS(time.Second) {
+		t.Errorf("expected %d, got %d\n", TS(time.Second), ts)
+	}
+
+	loc, err := time.LoadLocation("")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %s\n", err)
+	}
+	ts2, err := ParseInLocation(layout, "1970-01-01 00:00:01", loc)
+	if err != nil {
+		t.Fatalf("ParseInLocation failed: %s\n", err)
+	}
+	if !ts.Equal(ts2) {
+		t.Errorf("expected %s, got %s\n", ts, ts2)
+	}
+}
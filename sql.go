@@ -0,0 +1,86 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE.txt file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// GobEncode implements the gob.GobEncoder interface, delegating to
+// time.Time's gob wire format, so that TS stays compatible with
+// time.Time fields when gob-encoding heterogeneous structs.
+func (ts TS) GobEncode() ([]byte, error) {
+	return ts.Time().GobEncode()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (ts *TS) GobDecode(data []byte) error {
+	var t time.Time
+	if err := t.GobDecode(data); err != nil {
+		return err
+	}
+	*ts = Timestamp(t)
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface, returning
+// ts as a time.Time so it can be stored through any driver that already
+// supports time.Time columns.
+func (ts TS) Value() (driver.Value, error) {
+	return ts.Time(), nil
+}
+
+// Scan implements the database/sql.Scanner interface, accepting:
+//   - a time.Time, as returned by most drivers for timestamp columns
+//   - a []byte or string holding an RFC3339 timestamp
+//   - an int64, interpreted according to the package-wide format set
+//     with SetJSONFormat (seconds/milli/micro/nano since the Unix epoch)
+//   - nil, mapped to the zero TS
+//
+// Values outside the representable TS range are rejected with
+// ErrOutOfRange rather than silently clamped.
+func (ts *TS) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*ts = 0
+		return nil
+	case time.Time:
+		if OutOfRange(v) {
+			return ErrOutOfRange
+		}
+		*ts = Timestamp(v)
+		return nil
+	case []byte:
+		return ts.scanText(string(v))
+	case string:
+		return ts.scanText(v)
+	case int64:
+		ns, err := JSONFormatUsed().int64ToNanoseconds(v)
+		if err != nil {
+			return err
+		}
+		*ts = TS(ns)
+		return nil
+	default:
+		return fmt.Errorf("timestamp: unsupported Scan source type %T", src)
+	}
+}
+
+// scanText parses s as an RFC3339 timestamp for Scan.
+func (ts *TS) scanText(s string) error {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+	if OutOfRange(t) {
+		return ErrOutOfRange
+	}
+	*ts = Timestamp(t)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Intuitive Labs GmbH. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style license
+// that can be found in the LICENSE file in the root of the source
+// tree.
+
+package timestamp
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	ts := Timestamp(time.Now())
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ts); err != nil {
+		t.Fatalf("Encode failed: %s\n", err)
+	}
+	var ts2 TS
+	if err := gob.NewDecoder(&buf).Decode(&ts2); err != nil {
+		t.Fatalf("Decode failed: %s\n", err)
+	}
+	if !ts.Equal(ts2) {
+		t.Errorf("round trip failed: %s <> %s\n", ts, ts2)
+	}
+}
+
+func TestValue(t *testing.T) {
+	ts := Timestamp(time.Now())
+	v, err := ts.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %s\n", err)
+	}
+	u, ok := v.(time.Time)
+	if !ok || !ts.EqualTime(u) {
+		t.Errorf("Value mismatch: %v <> %s\n", v, ts)
+	}
+}
+
+func TestScanTime(t *testing.T) {
+	var ts TS
+	u := time.Now()
+	if err := ts.Scan(u); err != nil {
+		t.Fatalf("Scan failed: %s\n", err)
+	}
+	if !ts.EqualTime(u) {
+		t.Errorf("Scan mismatch: %s <> %s\n", ts, u)
+	}
+}
+
+func TestScanNil(t *testing.T) {
+	ts := Timestamp(time.Now())
+	if err := ts.Scan(nil); err != nil {
+		t.Fatalf("Scan failed: %s\n", err)
+	}
+	if !ts.IsZero() {
+		t.Errorf("expected zero TS, got %s\n", ts)
+	}
+}
+
+func TestScanString(t *testing.T) {
+	var ts TS
+	if err := ts.Scan("1970-01-01T00:00:01Z"); err != nil {
+		t.Fatalf("Scan failed: %s\n", err)
+	}
+	if ts != TS(time.Second) {
+		t.Errorf("expected %d, got %d\n", TS(time.Second), ts)
+	}
+}
+
+func TestScanInt64(t *testing.T) {
+	defer SetJSONFormat(FmtRFC3339)
+	SetJSONFormat(FmtUnixMilli)
+	var ts TS
+	if err := ts.Scan(int64(1500)); err != nil {
+		t.Fatalf("Scan failed: %s\n", err)
+	}
+	if ts != TS(1500*time.Millisecond) {
+		t.Errorf("expected %d, got %d\n", TS(1500*time.Millisecond), ts)
+	}
+}
+
+func TestScanOutOfRange(t *testing.T) {
+	var ts TS
+	err := ts.Scan(time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != ErrOutOfRange {
+		t.Errorf("expected ErrOutOfRange, got %v\n", err)
+	}
+}
+
+func TestScanUnsupported(t *testing.T) {
+	var ts TS
+	if err := ts.Scan(3.14); err == nil {
+		t.Errorf("expected an error for an unsupported Scan type\n")
+	}
+}
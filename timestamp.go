@@ -218,6 +218,31 @@ func (ts TS) TruncateTime(d time.Duration) time.Time {
 	return ts.Time().Truncate(d)
 }
 
+// Round returns the result of rounding ts to the nearest multiple of d
+// since the zero TS (see time.Round() for more details). As in
+// time.Time.Round, an exact tie is rounded up (toward the later of the
+// two candidate multiples), not away from zero. If d <= 0, Round returns
+// ts unchanged.
+// Implemented directly on the underlying nanoseconds, so it is
+// allocation-free and correct for negative TS values. Note that Round
+// does not guard against overflowing TS near MaxTS/MinTS (unlike
+// time.Duration.Round, which saturates): rounding a value close enough
+// to the edge of the representable range can wrap around.
+func (ts TS) Round(d time.Duration) TS {
+	if d <= 0 {
+		return ts
+	}
+	dd := TS(d)
+	r := ts % dd
+	if r < 0 {
+		r += dd
+	}
+	if r < dd-r {
+		return ts - r
+	}
+	return ts + (dd - r)
+}
+
 // Unix returns ts as Unix time (number of seconds since January 1, 1970 UTC)
 func (ts TS) Unix() int64 {
 	return ts.Time().Unix()
@@ -237,50 +262,6 @@ func (ts TS) String() string {
 	return ts.Time().String()
 }
 
-// MarshalBinary implements the encoding.BinaryMarshaler interface.
-func (ts TS) MarshalBinary() ([]byte, error) {
-	return ts.Time().MarshalBinary()
-}
-
-// MarshalJSON implements the json.Marshaler interface.
-func (ts TS) MarshalJSON() ([]byte, error) {
-	return ts.Time().MarshalJSON()
-}
-
-// MarshalText implements the encoding.TextMarshaler interface.
-func (ts TS) MarshalText() ([]byte, error) {
-	return ts.Time().MarshalText()
-}
-
-/*
-
-// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
-func (ts *TS) UnmarshalBinary(data []byte) error {
-	var t time.Time
-	var err error
-	if err = t.UnmarshalBinary(data); err == nil {
-		*ts = Timestamp(t)
-	}
-	return err
-}
-
-// UnmarshalJSON implements the json.Marshaler interface.
-func (ts *TS) UnmarshalJSON(data []byte) error {
-	var t time.Time
-	var err error
-	if err = t.UnmarshalJSON(data); err == nil {
-		*ts = Timestamp(t)
-	}
-	return err
-}
-
-// UnmarshalText implements the encoding.TextMarshaler interface.
-func (ts *TS) UnmarshalText(data []byte) error {
-	var t time.Time
-	var err error
-	if err = t.UnmarshalText(data); err == nil {
-		*ts = Timestamp(t)
-	}
-	return err
-}
-*/
+// MarshalBinary, MarshalJSON, MarshalText and the corresponding
+// Unmarshal* methods are implemented in format.go, together with the
+// compact JSON/text encoding modes (see SetJSONFormat).
@@ -47,6 +47,19 @@ func tstTruncateEq(t *testing.T, prefix string,
 	return true
 }
 
+func tstRoundEq(t *testing.T, prefix string,
+	ts TS, u time.Time, d time.Duration) bool {
+	if ts.Round(d).Time() != u.UTC().Round(d) {
+		t.Errorf(prefix+"failed Round equal test for: %s <> %s :"+
+			"rounded to %v: %s <> %s\n",
+			ts, u,
+			d,
+			ts.Round(d).Time(), u.UTC().Round(d))
+		return false
+	}
+	return true
+}
+
 func tstAdd(t *testing.T, prefix string,
 	ts TS, d time.Duration) int {
 	var errs int
@@ -104,6 +117,14 @@ func tstCmpEq(t *testing.T, prefix string, ts TS, u time.Time) int {
 		errs++
 	}
 
+	if !tstRoundEq(t, prefix, ts, u, time.Hour) ||
+		!tstRoundEq(t, prefix, ts, u, time.Minute) ||
+		!tstRoundEq(t, prefix, ts, u, time.Second) ||
+		!tstRoundEq(t, prefix, ts, u, time.Millisecond) ||
+		!tstRoundEq(t, prefix, ts, u, time.Microsecond) {
+		errs++
+	}
+
 	if ts.String() != u.UTC().String() {
 		t.Errorf(prefix+"failed String equal test: ts %s <> t %s\n", ts, u)
 		errs++